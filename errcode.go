@@ -0,0 +1,60 @@
+package http2
+
+import "fmt"
+
+// ErrCode 是 RFC 7540 §7 定义的错误码，出现在 RST_STREAM 和 GOAWAY 帧里。
+// 底层仍然是 uint32，因此既有的按 uint32 构造/比较的代码不需要改动即可继续工作。
+type ErrCode uint32
+
+const (
+	NoError            ErrCode = 0x0
+	ProtocolError      ErrCode = 0x1
+	InternalError      ErrCode = 0x2
+	FlowControlError   ErrCode = 0x3
+	SettingsTimeout    ErrCode = 0x4
+	StreamClosed       ErrCode = 0x5
+	FrameSizeError     ErrCode = 0x6
+	RefusedStream      ErrCode = 0x7
+	Cancel             ErrCode = 0x8
+	CompressionError   ErrCode = 0x9
+	ConnectError       ErrCode = 0xa
+	EnhanceYourCalm    ErrCode = 0xb
+	InadequateSecurity ErrCode = 0xc
+	HTTP11Required     ErrCode = 0xd
+)
+
+var errCodeNames = map[ErrCode]string{
+	NoError:            "NO_ERROR",
+	ProtocolError:      "PROTOCOL_ERROR",
+	InternalError:      "INTERNAL_ERROR",
+	FlowControlError:   "FLOW_CONTROL_ERROR",
+	SettingsTimeout:    "SETTINGS_TIMEOUT",
+	StreamClosed:       "STREAM_CLOSED",
+	FrameSizeError:     "FRAME_SIZE_ERROR",
+	RefusedStream:      "REFUSED_STREAM",
+	Cancel:             "CANCEL",
+	CompressionError:   "COMPRESSION_ERROR",
+	ConnectError:       "CONNECT_ERROR",
+	EnhanceYourCalm:    "ENHANCE_YOUR_CALM",
+	InadequateSecurity: "INADEQUATE_SECURITY",
+	HTTP11Required:     "HTTP_1_1_REQUIRED",
+}
+
+// String 实现 fmt.Stringer，未知错误码退化为十六进制表示。
+func (e ErrCode) String() string {
+	if name, ok := errCodeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("ERROR_CODE(0x%x)", uint32(e))
+}
+
+// FrameError 是携带了 RFC 7540 错误码的解析错误，调用方可以据此决定
+// 用 RST_STREAM 还是 GOAWAY 关闭连接/流，而不必再反过来猜测错误类型。
+type FrameError struct {
+	Code ErrCode
+	Msg  string
+}
+
+func (e *FrameError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Msg)
+}
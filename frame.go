@@ -19,7 +19,7 @@ type FrameHeader struct {
 	Length   uint32 // 24位长度
 	Type     uint8
 	Flags    uint8
-	StreamID uint32 // 31位流ID
+	StreamID StreamID // 31位流ID
 }
 
 // 通用帧接口
@@ -40,7 +40,7 @@ type HeadersFrame struct {
 	FrameHeader
 	PadLen           uint8
 	Exclusive        bool
-	StreamDependency uint32
+	StreamDependency StreamID
 	Weight           uint8
 	HeaderBlock      []byte
 }
@@ -52,7 +52,7 @@ type SettingsFrame struct {
 }
 
 type Setting struct {
-	ID    uint16
+	ID    SettingID
 	Value uint32
 }
 
@@ -82,7 +82,7 @@ func (h *FrameHeader) Serialize() []byte {
 	// 标志位1字节
 	buf[4] = h.Flags
 	// 流ID
-	binary.BigEndian.PutUint32(buf[5:9], h.StreamID&0x7FFFFFFF)
+	binary.BigEndian.PutUint32(buf[5:9], uint32(h.StreamID)&0x7FFFFFFF)
 	return buf
 }
 
@@ -95,7 +95,7 @@ func ParseFrameHeader(data []byte) (*FrameHeader, error) {
 		Length:   uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2]),
 		Type:     data[3],
 		Flags:    data[4],
-		StreamID: binary.BigEndian.Uint32(data[5:9]) & (1<<31 - 1),
+		StreamID: NewStreamID(binary.BigEndian.Uint32(data[5:9])),
 	}, nil
 }
 
@@ -109,7 +109,7 @@ func (f *HeadersFrame) Serialize() ([]byte, error) {
 	if f.StreamID == 0 {
 		return nil, fmt.Errorf("HEADERS_FRAME_ERROR: frame must have non-zero stream ID")
 	}
-	// 计算载荷长度
+	// 计算载荷长度：头部块本身，再加上填充和优先级各自的开销
 	payloadLength := uint32(len(f.HeaderBlock))
 
 	// 处理填充
@@ -117,7 +117,7 @@ func (f *HeadersFrame) Serialize() ([]byte, error) {
 		if f.PadLen > 255 {
 			return nil, fmt.Errorf("HEADERS_FRAME_ERROR: padLen is out of range: %d", f.PadLen)
 		}
-		payloadLength = 1 + uint32(f.PadLen)
+		payloadLength += 1 + uint32(f.PadLen)
 	}
 
 	// 处理优先级
@@ -147,11 +147,9 @@ func (f *HeadersFrame) Serialize() ([]byte, error) {
 
 	// 写入优先级信息
 	if hasPriority(f.Flags) {
-		var dep uint32
+		dep := uint32(f.StreamDependency) & 0x7FFFFFFF
 		if f.Exclusive {
-			dep = f.StreamDependency | 0x80000000 // 设置最高优先级
-		} else {
-			dep = f.StreamDependency | 0x7FFFFFFF
+			dep |= 0x80000000 // 设置最高优先级
 		}
 		binary.BigEndian.PutUint32(frame[offset:offset+4], dep)
 		offset += 4
@@ -169,6 +167,9 @@ func (f *HeadersFrame) Serialize() ([]byte, error) {
 
 // 解析 HeadersFrame
 func ParseHeadersFrame(header *FrameHeader, payload []byte) (*HeadersFrame, error) {
+	if header.StreamID == 0 {
+		return nil, &FrameError{Code: ProtocolError, Msg: "HEADERS frame must have a non-zero stream ID"}
+	}
 	frame := &HeadersFrame{
 		FrameHeader: *header,
 	}
@@ -190,7 +191,7 @@ func ParseHeadersFrame(header *FrameHeader, payload []byte) (*HeadersFrame, erro
 		}
 		dep := binary.BigEndian.Uint32(payload[offset : offset+4])
 		frame.Exclusive = dep&0x80000000 != 0     // 1位
-		frame.StreamDependency = dep & 0x7FFFFFFF // 31位
+		frame.StreamDependency = NewStreamID(dep) // 31位
 		offset += 4                               // Exclusive+StreamDependency共32位4字节
 		frame.Weight = payload[offset]
 		offset++
@@ -261,6 +262,9 @@ func ParseDataFrame(header *FrameHeader, payload []byte) (*DataFrame, error) {
 	if header.Type != FrameData {
 		return nil, fmt.Errorf("DATA_FRAME_ERROR: expected frame type %d, got %d", FrameData, header.Type)
 	}
+	if header.StreamID == 0 {
+		return nil, &FrameError{Code: ProtocolError, Msg: "DATA frame must have a non-zero stream ID"}
+	}
 	frame := &DataFrame{
 		FrameHeader: *header,
 	}
@@ -303,14 +307,15 @@ func (f *SettingsFrame) Serialize() ([]byte, error) {
 	copy(frame, header)
 
 	for _, setting := range f.Settings {
-		binary.BigEndian.PutUint16(frame[offset:], setting.ID)
+		binary.BigEndian.PutUint16(frame[offset:], uint16(setting.ID))
 		binary.BigEndian.PutUint32(frame[offset+2:], setting.Value)
 		offset += 6
 	}
 	return frame, nil
 }
 
-// SettingsFrame 解析
+// SettingsFrame 解析。已知参数的取值按 RFC 7540 §6.5.2 校验，不合法时返回携带
+// 对应 ErrCode 的 *FrameError；未知的参数标识符被静默忽略，不当作错误处理。
 func ParseSettingsFrame(header *FrameHeader, payload []byte) (*SettingsFrame, error) {
 	if header.StreamID != 0 {
 		return nil, fmt.Errorf("SETTINGS_FRAME_ERROR: streamID must be zero")
@@ -322,10 +327,12 @@ func ParseSettingsFrame(header *FrameHeader, payload []byte) (*SettingsFrame, er
 	settings := make([]Setting, len(payload)/6)
 	for i := 0; i < len(settings); i++ {
 		offset := i * 6
-		settings[i] = Setting{
-			ID:    binary.BigEndian.Uint16(payload[offset : offset+2]),
-			Value: binary.BigEndian.Uint32(payload[offset+2 : offset+6]),
+		id := SettingID(binary.BigEndian.Uint16(payload[offset : offset+2]))
+		value := binary.BigEndian.Uint32(payload[offset+2 : offset+6])
+		if err := validateSetting(id, value); err != nil {
+			return nil, err
 		}
+		settings[i] = Setting{ID: id, Value: value}
 	}
 	return &SettingsFrame{
 		FrameHeader: *header,
@@ -364,7 +371,7 @@ func (f *PingFrame) Serialize() ([]byte, error) {
 
 func ParsePingFrame(header *FrameHeader, payload []byte) (*PingFrame, error) {
 	if header.Type != FramePing {
-		return nil, fmt.Errorf("PING_FRAME_ERROR: expected frame type %d, got %d")
+		return nil, fmt.Errorf("PING_FRAME_ERROR: expected frame type %d, got %d", FramePing, header.Type)
 	}
 	if header.StreamID != 0 {
 		return nil, fmt.Errorf("PING_FRAME_ERROR: streamID must be zero")
@@ -416,11 +423,11 @@ func ParseWindowUpdateFrame(header *FrameHeader, payload []byte) (*WindowUpdateF
 // =========================== RSTStreamFrame ===========================
 type RSTStreamFrame struct {
 	FrameHeader
-	ErrorCode uint32
+	ErrorCode ErrCode
 }
 
 func (f *RSTStreamFrame) Serialize() ([]byte, error) {
-	if f.ErrorCode == 0 {
+	if f.StreamID == 0 {
 		return nil, fmt.Errorf("RST_STREAM_FRAME_ERROR: streamID must be greater than zero")
 	}
 	f.Length = 4
@@ -428,7 +435,7 @@ func (f *RSTStreamFrame) Serialize() ([]byte, error) {
 	offset := len(header)
 	frame := make([]byte, offset+4)
 	copy(frame, header)
-	binary.BigEndian.PutUint32(frame[offset:], f.ErrorCode)
+	binary.BigEndian.PutUint32(frame[offset:], uint32(f.ErrorCode))
 	return frame, nil
 }
 
@@ -442,16 +449,19 @@ func ParseRSTStreamFrame(header *FrameHeader, payload []byte) (*RSTStreamFrame,
 	if header.Length != 4 {
 		return nil, fmt.Errorf("RST_STREAMFRAME_ERROR: invalid length: %d", header.Length)
 	}
+	if header.StreamID == 0 {
+		return nil, &FrameError{Code: ProtocolError, Msg: "RST_STREAM frame must have a non-zero stream ID"}
+	}
 	return &RSTStreamFrame{
 		FrameHeader: *header,
-		ErrorCode:   binary.BigEndian.Uint32(payload),
+		ErrorCode:   ErrCode(binary.BigEndian.Uint32(payload)),
 	}, nil
 }
 
 type GoAwayFrame struct {
 	FrameHeader
-	LastStreamID uint32
-	ErrorCode    uint32
+	LastStreamID StreamID
+	ErrorCode    ErrCode
 	DebugData    []byte
 }
 
@@ -464,9 +474,9 @@ func (f *GoAwayFrame) Serialize() ([]byte, error) {
 	offset := len(header)
 	frame := make([]byte, offset+int(f.Length))
 	copy(frame, header)
-	binary.BigEndian.PutUint32(frame[offset:offset+4], f.LastStreamID&0x7FFFFFFF)
+	binary.BigEndian.PutUint32(frame[offset:offset+4], uint32(f.LastStreamID)&0x7FFFFFFF)
 	offset += 4
-	binary.BigEndian.PutUint32(frame[offset+4:offset+8], f.ErrorCode)
+	binary.BigEndian.PutUint32(frame[offset:offset+4], uint32(f.ErrorCode))
 	offset += 4
 	copy(frame[offset:], f.DebugData)
 	return frame, nil
@@ -482,11 +492,11 @@ func ParseGoAwayFrame(header *FrameHeader, payload []byte) (*GoAwayFrame, error)
 	if header.StreamID != 0 {
 		return nil, fmt.Errorf("GOWAYFRAME_ERROR: streamID must be zero")
 	}
-	lastStreamID := binary.BigEndian.Uint32(payload[:4]) & 0x7FFFFFFF
-	errorCode := binary.BigEndian.Uint32(payload[4:8])
+	lastStreamID := NewStreamID(binary.BigEndian.Uint32(payload[:4]))
+	errorCode := ErrCode(binary.BigEndian.Uint32(payload[4:8]))
 	debugData := make([]byte, header.Length-8)
 	if header.Length > 8 {
-		copy(debugData, payload[8:header.Length])
+		copy(debugData, payload[8:len(payload)])
 	}
 	return &GoAwayFrame{
 		FrameHeader:  *header,
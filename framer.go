@@ -0,0 +1,256 @@
+package http2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/shgang97/http2/hpack"
+)
+
+// UnknownFrame 表示一个帧类型未知的帧。
+// 按照 RFC 7540 §4.1 的要求，实现必须忽略未知类型的帧，而不是将其当作错误处理，
+// 因此 ReadFrame 会把这类帧包装成 UnknownFrame 返回给调用方，由调用方决定如何处理。
+type UnknownFrame struct {
+	FrameHeader
+	Payload []byte
+}
+
+func (f *UnknownFrame) Serialize() ([]byte, error) {
+	header := f.FrameHeader.Serialize()
+	frame := make([]byte, len(header)+len(f.Payload))
+	copy(frame, header)
+	copy(frame[len(header):], f.Payload)
+	return frame, nil
+}
+
+// Framer 在一对 io.Reader/io.Writer 上提供按帧读写的能力，
+// 调用方不再需要手动处理长度前缀、缓冲区管理和分帧循环。
+type Framer struct {
+	r io.Reader
+	w io.Writer
+
+	// maxFrameSize 是对端通告的 SETTINGS_MAX_FRAME_SIZE，用于约束 ReadFrame 能接受的最大载荷。
+	maxFrameSize uint32
+
+	// headerBuf 是读取 9 字节帧头部时复用的暂存区，避免每次读帧都重新分配。
+	headerBuf [9]byte
+
+	// enc/dec 是这条连接两个方向各自的 HPACK 状态，动态表在多次调用之间存活。
+	enc *hpack.Encoder
+	dec *hpack.Decoder
+}
+
+// NewFramer 创建一个新的 Framer，使用 RFC 7540 §4.2 规定的默认最大帧大小。
+func NewFramer(rw io.ReadWriter) *Framer {
+	return &Framer{
+		r:            bufio.NewReader(rw),
+		w:            rw,
+		maxFrameSize: MaxFrameSize,
+		enc:          hpack.NewEncoder(),
+		dec:          hpack.NewDecoder(hpack.DefaultMaxDynamicTableSize),
+	}
+}
+
+// SetMaxFrameSize 更新对端通告的 SETTINGS_MAX_FRAME_SIZE，之后的 ReadFrame 调用将据此校验载荷长度。
+func (fr *Framer) SetMaxFrameSize(size uint32) {
+	fr.maxFrameSize = size
+}
+
+// ReadFrame 读取一个完整的帧：先读取 9 字节头部，再根据 Type 分发到对应的 Parse* 函数。
+// 未知的帧类型不会返回错误，而是以 *UnknownFrame 的形式返回，交由调用方按 RFC 7540 §4.1 忽略。
+//
+// 如果读到的是缺少 END_HEADERS 的 HEADERS 或 PUSH_PROMISE 帧，ReadFrame 会继续读取后续帧，
+// 要求它们必须是同一个流上的 CONTINUATION 帧，直到遇到设置了 END_HEADERS 的那一个为止，
+// 并把所有分片拼接成一个完整的头部块后再把帧返回给调用方；其间出现任何其他帧都是 PROTOCOL_ERROR。
+func (fr *Framer) ReadFrame() (Frame, error) {
+	header, payload, err := fr.readRawFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	frame, err := fr.parseFrame(header, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch f := frame.(type) {
+	case *HeadersFrame:
+		if !hasEndHeaders(f.Flags) {
+			if err := fr.collectContinuations(&f.HeaderBlock, f.StreamID, &f.Flags); err != nil {
+				return nil, err
+			}
+		}
+	case *PushPromiseFrame:
+		if !hasEndHeaders(f.Flags) {
+			if err := fr.collectContinuations(&f.HeaderBlock, f.StreamID, &f.Flags); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return frame, nil
+}
+
+// readRawFrame 读取下一个帧的头部和载荷，但不做任何类型相关的解析。
+func (fr *Framer) readRawFrame() (*FrameHeader, []byte, error) {
+	if _, err := io.ReadFull(fr.r, fr.headerBuf[:]); err != nil {
+		return nil, nil, err
+	}
+	header, err := ParseFrameHeader(fr.headerBuf[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Length > fr.maxFrameSize {
+		return nil, nil, fmt.Errorf("FRAME_SIZE_ERROR: frame length %d exceeds max frame size %d", header.Length, fr.maxFrameSize)
+	}
+
+	payload := make([]byte, header.Length)
+	if _, err := io.ReadFull(fr.r, payload); err != nil {
+		return nil, nil, err
+	}
+	return header, payload, nil
+}
+
+// parseFrame 把一个已经读出的帧头部和载荷分发给对应类型的 Parse* 函数。
+func (fr *Framer) parseFrame(header *FrameHeader, payload []byte) (Frame, error) {
+	switch header.Type {
+	case FrameData:
+		return ParseDataFrame(header, payload)
+	case FrameHeaders:
+		return ParseHeadersFrame(header, payload)
+	case FramePriority:
+		return ParsePriorityFrame(header, payload)
+	case FrameSettings:
+		frame, err := ParseSettingsFrame(header, payload)
+		if err != nil {
+			return nil, err
+		}
+		fr.applyPeerSettings(frame)
+		return frame, nil
+	case FramePushPromise:
+		return ParsePushPromiseFrame(header, payload)
+	case FramePing:
+		return ParsePingFrame(header, payload)
+	case FrameGoWay:
+		return ParseGoAwayFrame(header, payload)
+	case FrameWindowUpdate:
+		return ParseWindowUpdateFrame(header, payload)
+	case FrameRSTStream:
+		return ParseRSTStreamFrame(header, payload)
+	case FrameContinuation:
+		// 游离的 CONTINUATION 帧（不紧跟在缺少 END_HEADERS 的 HEADERS/PUSH_PROMISE 之后）
+		// 本身就是协议错误，由 collectContinuations 以外的路径读到时同样要拒绝。
+		return nil, fmt.Errorf("PROTOCOL_ERROR: unexpected CONTINUATION frame on stream %d", header.StreamID)
+	default:
+		return &UnknownFrame{FrameHeader: *header, Payload: payload}, nil
+	}
+}
+
+// collectContinuations 在 HEADERS/PUSH_PROMISE 帧缺少 END_HEADERS 时读取后续帧，
+// 只允许同一流上的 CONTINUATION 帧出现，直到拼出完整的头部块。
+func (fr *Framer) collectContinuations(block *[]byte, streamID StreamID, flags *uint8) error {
+	for {
+		header, payload, err := fr.readRawFrame()
+		if err != nil {
+			return err
+		}
+		if header.Type != FrameContinuation || header.StreamID != streamID {
+			return fmt.Errorf("PROTOCOL_ERROR: expected CONTINUATION frame for stream %d, got type %d on stream %d", streamID, header.Type, header.StreamID)
+		}
+		cont, err := ParseContinuationFrame(header, payload)
+		if err != nil {
+			return err
+		}
+		*block = append(*block, cont.HeaderBlockFragment...)
+		if hasEndHeaders(header.Flags) {
+			*flags |= FlagEndHeaders
+			return nil
+		}
+	}
+}
+
+func hasEndHeaders(flags uint8) bool {
+	return flags&FlagEndHeaders != 0
+}
+
+// WriteFrame 序列化并原子性地写出一个帧。过大的载荷会在 Serialize 阶段被拒绝，
+// 这里不再重复校验长度，直接依赖各帧类型自身的限制。
+func (fr *Framer) WriteFrame(f Frame) error {
+	data, err := f.Serialize()
+	if err != nil {
+		return err
+	}
+	if len(data) > 9+int(fr.maxFrameSize) {
+		return fmt.Errorf("FRAME_SIZE_ERROR: frame payload %d exceeds max frame size %d", len(data)-9, fr.maxFrameSize)
+	}
+	_, err = fr.w.Write(data)
+	return err
+}
+
+// applyPeerSettings 在收到对端的 SETTINGS 帧后，把其中的 SETTINGS_HEADER_TABLE_SIZE
+// 应用到本地的 HPACK 编码器：对端通过这个参数声明了它的解码动态表愿意接受的上限。
+func (fr *Framer) applyPeerSettings(frame *SettingsFrame) {
+	for _, s := range frame.Settings {
+		if s.ID == SettingsHeaderTableSize {
+			fr.enc.SetMaxDynamicTableSize(s.Value)
+		}
+	}
+}
+
+// SetHeaderTableSize 设置本地准备通过 SETTINGS_HEADER_TABLE_SIZE 向对端声明的值，
+// 并据此调整用于解码对端 HEADERS 帧的动态表上限。
+func (fr *Framer) SetHeaderTableSize(size uint32) {
+	fr.dec.SetMaxDynamicTableSize(size)
+}
+
+// WriteHeaders 把 headers 编码为 HPACK 头部块并写出一个 HEADERS 帧。
+// 编码使用的动态表在这个 Framer 的整个生命周期内持续存在，因此调用方无需自行缓存状态。
+func (fr *Framer) WriteHeaders(streamID StreamID, headers http.Header, endStream bool) error {
+	fields := headerFieldsFromHTTP(headers)
+	block, err := fr.enc.Encode(fields)
+	if err != nil {
+		return err
+	}
+
+	flags := uint8(FlagEndHeaders)
+	if endStream {
+		flags |= FlagEndStream
+	}
+	frame := &HeadersFrame{
+		FrameHeader: FrameHeader{
+			Type:     FrameHeaders,
+			Flags:    flags,
+			StreamID: streamID,
+		},
+		HeaderBlock: block,
+	}
+	return fr.WriteFrame(frame)
+}
+
+// DecodeHeaders 解码一个 HeadersFrame 的 HeaderBlock。
+// 调用方负责先把跟随的 CONTINUATION 帧拼接进 HeaderBlock，这里只做 HPACK 解码。
+func (fr *Framer) DecodeHeaders(frame *HeadersFrame) ([]hpack.HeaderField, error) {
+	return fr.dec.Decode(frame.HeaderBlock)
+}
+
+// headerFieldsFromHTTP 把 http.Header 展开为 HPACK 字段列表，并按 RFC 7540 §8.1.2
+// 的要求把字段名转换为小写；键按字典序排序以保证同样的输入总是编码出同样的字节。
+func headerFieldsFromHTTP(headers http.Header) []hpack.HeaderField {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var fields []hpack.HeaderField
+	for _, k := range keys {
+		name := strings.ToLower(k)
+		for _, v := range headers[k] {
+			fields = append(fields, hpack.HeaderField{Name: name, Value: v})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,165 @@
+package http2
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// =========================== ContinuationFrame ===========================
+// 延续帧：当 HEADERS 或 PUSH_PROMISE 帧没有设置 END_HEADERS 时，
+// 紧随其后的若干个 CONTINUATION 帧携带头部块剩余的片段。
+type ContinuationFrame struct {
+	FrameHeader
+	HeaderBlockFragment []byte
+}
+
+func (f *ContinuationFrame) Serialize() ([]byte, error) {
+	if f.StreamID == 0 {
+		return nil, fmt.Errorf("CONTINUATION_FRAME_ERROR: frame must have non-zero stream ID")
+	}
+	f.Length = uint32(len(f.HeaderBlockFragment))
+	header := f.FrameHeader.Serialize()
+	offset := len(header)
+	frame := make([]byte, offset+len(f.HeaderBlockFragment))
+	copy(frame, header)
+	copy(frame[offset:], f.HeaderBlockFragment)
+	return frame, nil
+}
+
+func ParseContinuationFrame(header *FrameHeader, payload []byte) (*ContinuationFrame, error) {
+	if header.Type != FrameContinuation {
+		return nil, fmt.Errorf("CONTINUATION_FRAME_ERROR: expected frame type %d, got %d", FrameContinuation, header.Type)
+	}
+	if header.StreamID == 0 {
+		return nil, fmt.Errorf("CONTINUATION_FRAME_ERROR: streamID must be non-zero")
+	}
+	frame := &ContinuationFrame{FrameHeader: *header}
+	frame.HeaderBlockFragment = make([]byte, len(payload))
+	copy(frame.HeaderBlockFragment, payload)
+	return frame, nil
+}
+
+// =========================== PushPromiseFrame ===========================
+// 推送承诺帧：服务端在 PromisedStreamID 上声明即将推送的资源，
+// HeaderBlock 携带被推送请求的头部（可能需要后续 CONTINUATION 帧补全）。
+type PushPromiseFrame struct {
+	FrameHeader
+	PadLen           uint8
+	PromisedStreamID StreamID
+	HeaderBlock      []byte
+}
+
+func (f *PushPromiseFrame) Serialize() ([]byte, error) {
+	if f.StreamID == 0 {
+		return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: frame must have non-zero stream ID")
+	}
+
+	payloadLength := uint32(4 + len(f.HeaderBlock))
+	if hasPad(f.Flags) {
+		if f.PadLen > 255 {
+			return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: padLen is out of range: %d", f.PadLen)
+		}
+		payloadLength += 1 + uint32(f.PadLen)
+	}
+	if payloadLength > 0xFFFFFF {
+		return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: payloadLength is out of range: %d", payloadLength)
+	}
+	f.Length = payloadLength
+
+	header := f.FrameHeader.Serialize()
+	offset := len(header)
+	frame := make([]byte, offset+int(payloadLength))
+	copy(frame, header)
+
+	if hasPad(f.Flags) {
+		frame[offset] = f.PadLen
+		offset++
+	}
+
+	binary.BigEndian.PutUint32(frame[offset:offset+4], uint32(f.PromisedStreamID)&0x7FFFFFFF)
+	offset += 4
+
+	copy(frame[offset:], f.HeaderBlock)
+	// 填充区已经是0值，无需操作
+	return frame, nil
+}
+
+func ParsePushPromiseFrame(header *FrameHeader, payload []byte) (*PushPromiseFrame, error) {
+	if header.Type != FramePushPromise {
+		return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: expected frame type %d, got %d", FramePushPromise, header.Type)
+	}
+	if header.StreamID == 0 {
+		return nil, &FrameError{Code: ProtocolError, Msg: "PUSH_PROMISE frame must have a non-zero stream ID"}
+	}
+	frame := &PushPromiseFrame{FrameHeader: *header}
+	offset := 0
+
+	if hasPad(header.Flags) {
+		if len(payload) < 1 {
+			return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: pad is required")
+		}
+		frame.PadLen = payload[0]
+		offset++
+	}
+
+	if len(payload) < offset+4 {
+		return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: promised stream ID is required")
+	}
+	frame.PromisedStreamID = NewStreamID(binary.BigEndian.Uint32(payload[offset : offset+4]))
+	offset += 4
+
+	headerBlockLength := len(payload) - offset - int(frame.PadLen)
+	if headerBlockLength < 0 {
+		return nil, fmt.Errorf("PUSH_PROMISE_FRAME_ERROR: header block length must not be negative")
+	}
+	frame.HeaderBlock = payload[offset : offset+headerBlockLength]
+	return frame, nil
+}
+
+// =========================== PriorityFrame ===========================
+// 优先级帧：声明一个流相对于 StreamDependency 的权重，与 HEADERS 帧里的
+// 优先级字段共用同样的编码（RFC 7540 §6.2 的 5 字节优先级信息）。
+type PriorityFrame struct {
+	FrameHeader
+	Exclusive        bool
+	StreamDependency StreamID
+	Weight           uint8
+}
+
+func (f *PriorityFrame) Serialize() ([]byte, error) {
+	if f.StreamID == 0 {
+		return nil, fmt.Errorf("PRIORITY_FRAME_ERROR: frame must have non-zero stream ID")
+	}
+	f.Length = 5
+	header := f.FrameHeader.Serialize()
+	offset := len(header)
+	frame := make([]byte, offset+5)
+	copy(frame, header)
+
+	dep := uint32(f.StreamDependency) & 0x7FFFFFFF
+	if f.Exclusive {
+		dep |= 0x80000000
+	}
+	binary.BigEndian.PutUint32(frame[offset:offset+4], dep)
+	frame[offset+4] = f.Weight
+	return frame, nil
+}
+
+func ParsePriorityFrame(header *FrameHeader, payload []byte) (*PriorityFrame, error) {
+	if header.Type != FramePriority {
+		return nil, fmt.Errorf("PRIORITY_FRAME_ERROR: expected frame type %d, got %d", FramePriority, header.Type)
+	}
+	if header.StreamID == 0 {
+		return nil, fmt.Errorf("PRIORITY_FRAME_ERROR: streamID must be non-zero")
+	}
+	if len(payload) != 5 {
+		return nil, fmt.Errorf("PRIORITY_FRAME_ERROR: invalid payload length: %d", len(payload))
+	}
+	dep := binary.BigEndian.Uint32(payload[0:4])
+	return &PriorityFrame{
+		FrameHeader:      *header,
+		Exclusive:        dep&0x80000000 != 0,
+		StreamDependency: NewStreamID(dep),
+		Weight:           payload[4],
+	}, nil
+}
@@ -0,0 +1,226 @@
+package http2
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// frameFuzzSeeds 是一组能够被每种帧类型正确解析的已知良好载荷，
+// 作为 FuzzParseFrame 的初始语料库，帮助模糊测试器更快发现边界附近的变异。
+var frameFuzzSeeds = [][]byte{
+	mustSerialize(&DataFrame{
+		FrameHeader: FrameHeader{Type: FrameData, StreamID: 1},
+		Data:        []byte("hello"),
+	}),
+	mustSerialize(&HeadersFrame{
+		FrameHeader: FrameHeader{Type: FrameHeaders, Flags: FlagEndHeaders | FlagEndStream, StreamID: 1},
+		HeaderBlock: []byte{0x82, 0x86, 0x84},
+	}),
+	mustSerialize(&SettingsFrame{
+		FrameHeader: FrameHeader{Type: FrameSettings},
+		Settings:    []Setting{{ID: SettingsMaxFrameSize, Value: MaxFrameSize}},
+	}),
+	mustSerialize(&PingFrame{FrameHeader: FrameHeader{Type: FramePing}}),
+	mustSerialize(&WindowUpdateFrame{FrameHeader: FrameHeader{Type: FrameWindowUpdate, StreamID: 1}, WindowSizeIncrement: 100}),
+	mustSerialize(&RSTStreamFrame{FrameHeader: FrameHeader{Type: FrameRSTStream, StreamID: 1}, ErrorCode: NoError}),
+	mustSerialize(&GoAwayFrame{FrameHeader: FrameHeader{Type: FrameGoWay}, LastStreamID: 3, ErrorCode: ProtocolError}),
+	mustSerialize(&PriorityFrame{FrameHeader: FrameHeader{Type: FramePriority, StreamID: 1}, StreamDependency: 3, Weight: 16}),
+	mustSerialize(&PushPromiseFrame{FrameHeader: FrameHeader{Type: FramePushPromise, Flags: FlagEndHeaders, StreamID: 1}, PromisedStreamID: 2}),
+}
+
+func mustSerialize(f Frame) []byte {
+	data, err := f.Serialize()
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// FuzzParseFrame 把任意字节喂给 Framer.ReadFrame，对成功解析出来的帧重新序列化，
+// 再次读取并断言结果与原始帧一致，以此验证读写路径是往返对称的。
+func FuzzParseFrame(f *testing.F) {
+	for _, seed := range frameFuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fr := NewFramer(bytes.NewBuffer(append([]byte(nil), data...)))
+		frame, err := fr.ReadFrame()
+		if err != nil {
+			return
+		}
+
+		reserialized, err := frame.Serialize()
+		if err != nil {
+			t.Fatalf("Serialize of successfully parsed frame failed: %v", err)
+		}
+
+		fr2 := NewFramer(bytes.NewBuffer(reserialized))
+		frame2, err := fr2.ReadFrame()
+		if err != nil {
+			t.Fatalf("re-parsing a re-serialized frame failed: %v", err)
+		}
+		if !reflect.DeepEqual(frame, frame2) {
+			t.Fatalf("round-trip mismatch:\n got  %#v\n want %#v", frame2, frame)
+		}
+	})
+}
+
+func FuzzDataFrame(f *testing.F) {
+	f.Add(uint32(1), uint8(0), []byte("payload"))
+	f.Fuzz(func(t *testing.T, streamID uint32, flags uint8, payload []byte) {
+		header := &FrameHeader{Type: FrameData, Flags: flags, StreamID: NewStreamID(streamID)}
+		frame, err := ParseDataFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzHeadersFrame(f *testing.F) {
+	f.Add(uint32(1), uint8(FlagEndHeaders), []byte{0x82, 0x86})
+	f.Fuzz(func(t *testing.T, streamID uint32, flags uint8, payload []byte) {
+		header := &FrameHeader{Type: FrameHeaders, Flags: flags, StreamID: NewStreamID(streamID)}
+		frame, err := ParseHeadersFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzSettingsFrame(f *testing.F) {
+	f.Add([]byte{0x00, 0x05, 0x00, 0x00, 0x40, 0x00})
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		header := &FrameHeader{Type: FrameSettings}
+		frame, err := ParseSettingsFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzPingFrame(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		header := &FrameHeader{Type: FramePing}
+		frame, err := ParsePingFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzWindowUpdateFrame(f *testing.F) {
+	f.Add(uint32(1), []byte{0, 0, 0, 100})
+	f.Fuzz(func(t *testing.T, streamID uint32, payload []byte) {
+		header := &FrameHeader{Type: FrameWindowUpdate, Length: uint32(len(payload)), StreamID: NewStreamID(streamID)}
+		frame, err := ParseWindowUpdateFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzRSTStreamFrame(f *testing.F) {
+	f.Add(uint32(1), []byte{0, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, streamID uint32, payload []byte) {
+		header := &FrameHeader{Type: FrameRSTStream, Length: uint32(len(payload)), StreamID: NewStreamID(streamID)}
+		frame, err := ParseRSTStreamFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzGoAwayFrame(f *testing.F) {
+	f.Add([]byte{0, 0, 0, 3, 0, 0, 0, 1, 'd', 'b', 'g'})
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		header := &FrameHeader{Type: FrameGoWay, Length: uint32(len(payload))}
+		frame, err := ParseGoAwayFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzPriorityFrame(f *testing.F) {
+	f.Add(uint32(1), []byte{0x80, 0, 0, 3, 16})
+	f.Fuzz(func(t *testing.T, streamID uint32, payload []byte) {
+		header := &FrameHeader{Type: FramePriority, StreamID: NewStreamID(streamID)}
+		frame, err := ParsePriorityFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzPushPromiseFrame(f *testing.F) {
+	f.Add(uint32(1), uint8(FlagEndHeaders), []byte{0, 0, 0, 2, 0x82})
+	f.Fuzz(func(t *testing.T, streamID uint32, flags uint8, payload []byte) {
+		header := &FrameHeader{Type: FramePushPromise, Flags: flags, StreamID: NewStreamID(streamID)}
+		frame, err := ParsePushPromiseFrame(header, payload)
+		if err != nil {
+			return
+		}
+		roundTripFrame(t, frame)
+	})
+}
+
+func FuzzContinuationFrame(f *testing.F) {
+	f.Add(uint32(1), []byte{0x82, 0x86})
+	f.Fuzz(func(t *testing.T, streamID uint32, payload []byte) {
+		header := &FrameHeader{Type: FrameContinuation, StreamID: NewStreamID(streamID)}
+		frame, err := ParseContinuationFrame(header, payload)
+		if err != nil {
+			return
+		}
+		// CONTINUATION 帧只有紧跟在未设置 END_HEADERS 的 HEADERS/PUSH_PROMISE 之后才合法，
+		// Framer.parseFrame 会拒绝游离的 CONTINUATION 帧，因此这里直接用 Parse* 往返验证。
+		data, err := frame.Serialize()
+		if err != nil {
+			t.Fatalf("Serialize failed: %v", err)
+		}
+		reheader, err := ParseFrameHeader(data[:9])
+		if err != nil {
+			t.Fatalf("ParseFrameHeader failed on re-serialized data: %v", err)
+		}
+		reparsed, err := ParseContinuationFrame(reheader, data[9:])
+		if err != nil {
+			t.Fatalf("re-parsing a re-serialized frame failed: %v", err)
+		}
+		if !reflect.DeepEqual(frame, reparsed) {
+			t.Fatalf("round-trip mismatch:\n got  %#v\n want %#v", reparsed, frame)
+		}
+	})
+}
+
+// roundTripFrame 序列化 frame，重新解析帧头部和载荷，并断言得到的帧与原始帧相等。
+func roundTripFrame(t *testing.T, frame Frame) {
+	t.Helper()
+	data, err := frame.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	header, err := ParseFrameHeader(data[:9])
+	if err != nil {
+		t.Fatalf("ParseFrameHeader failed on re-serialized data: %v", err)
+	}
+
+	fr := NewFramer(bytes.NewBuffer(nil))
+	reparsed, err := fr.parseFrame(header, data[9:])
+	if err != nil {
+		t.Fatalf("re-parsing a re-serialized frame failed: %v", err)
+	}
+	if !reflect.DeepEqual(frame, reparsed) {
+		t.Fatalf("round-trip mismatch:\n got  %#v\n want %#v", reparsed, frame)
+	}
+}
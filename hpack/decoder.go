@@ -0,0 +1,139 @@
+package hpack
+
+import "fmt"
+
+// Decoder 维护一个方向上的 HPACK 解码状态（即动态表），在一条连接的生命周期内
+// 跨多次 Decode 调用保持动态表存活。
+type Decoder struct {
+	dyn *dynamicTable
+}
+
+// NewDecoder 创建一个动态表上限为 maxSize 的 Decoder。
+func NewDecoder(maxSize uint32) *Decoder {
+	return &Decoder{dyn: newDynamicTable(maxSize)}
+}
+
+// SetMaxDynamicTableSize 根据本地通告的 SETTINGS_HEADER_TABLE_SIZE 调整解码器
+// 动态表允许达到的上限。
+func (d *Decoder) SetMaxDynamicTableSize(size uint32) {
+	d.dyn.setMaxSize(size)
+}
+
+// Decode 把一段 HPACK 头部块解码为有序的 HeaderField 列表。
+func (d *Decoder) Decode(data []byte) ([]HeaderField, error) {
+	var fields []HeaderField
+	p := data
+	for len(p) > 0 {
+		b := p[0]
+		switch {
+		case b&0x80 != 0: // Indexed Header Field
+			idx, n, err := readVarInt(7, p)
+			if err != nil {
+				return nil, err
+			}
+			p = p[n:]
+			f, ok := entryAt(d.dyn, int(idx))
+			if !ok {
+				return nil, fmt.Errorf("hpack: invalid index %d", idx)
+			}
+			fields = append(fields, f)
+
+		case b&0xc0 == 0x40: // Literal Header Field with Incremental Indexing
+			f, n, err := d.decodeLiteral(p, 6)
+			if err != nil {
+				return nil, err
+			}
+			p = p[n:]
+			fields = append(fields, f)
+			d.dyn.add(f)
+
+		case b&0xe0 == 0x20: // Dynamic Table Size Update
+			size, n, err := readVarInt(5, p)
+			if err != nil {
+				return nil, err
+			}
+			p = p[n:]
+			d.dyn.setMaxSize(uint32(size))
+
+		case b&0xf0 == 0x10: // Literal Header Field Never Indexed
+			f, n, err := d.decodeLiteral(p, 4)
+			if err != nil {
+				return nil, err
+			}
+			f.Sensitive = true
+			p = p[n:]
+			fields = append(fields, f)
+
+		case b&0xf0 == 0x00: // Literal Header Field without Indexing
+			f, n, err := d.decodeLiteral(p, 4)
+			if err != nil {
+				return nil, err
+			}
+			p = p[n:]
+			fields = append(fields, f)
+
+		default:
+			return nil, fmt.Errorf("hpack: invalid representation byte 0x%02x", b)
+		}
+	}
+	return fields, nil
+}
+
+// decodeLiteral 解码字面量表示形式共用的部分：一个 prefixBits 位的索引，
+// 索引为 0 时紧跟字面量名称，随后总是紧跟字面量值。返回消耗的字节数。
+func (d *Decoder) decodeLiteral(p []byte, prefixBits byte) (HeaderField, int, error) {
+	idx, n, err := readVarInt(prefixBits, p)
+	if err != nil {
+		return HeaderField{}, 0, err
+	}
+	offset := n
+
+	var name string
+	if idx == 0 {
+		s, sn, err := decodeString(p[offset:])
+		if err != nil {
+			return HeaderField{}, 0, err
+		}
+		name = s
+		offset += sn
+	} else {
+		e, ok := entryAt(d.dyn, int(idx))
+		if !ok {
+			return HeaderField{}, 0, fmt.Errorf("hpack: invalid index %d", idx)
+		}
+		name = e.Name
+	}
+
+	value, sn, err := decodeString(p[offset:])
+	if err != nil {
+		return HeaderField{}, 0, err
+	}
+	offset += sn
+
+	return HeaderField{Name: name, Value: value}, offset, nil
+}
+
+// decodeString 解码一个 RFC 7541 §5.2 字符串字面量，返回解出的字符串和消耗的字节数。
+func decodeString(p []byte) (string, int, error) {
+	if len(p) == 0 {
+		return "", 0, fmt.Errorf("hpack: empty string literal")
+	}
+	huffman := p[0]&0x80 != 0
+	length, n, err := readVarInt(7, p)
+	if err != nil {
+		return "", 0, err
+	}
+	total := n + int(length)
+	if total > len(p) {
+		return "", 0, fmt.Errorf("hpack: truncated string literal")
+	}
+	raw := p[n:total]
+	if huffman {
+		s, err := HuffmanDecode(raw)
+		if err != nil {
+			return "", 0, err
+		}
+		return s, total, nil
+	}
+	return string(raw), total, nil
+}
@@ -0,0 +1,84 @@
+package hpack
+
+// Encoder 维护一个方向上的 HPACK 编码状态（即动态表），可反复调用 Encode
+// 编码多个 HEADERS/CONTINUATION 序列，动态表在调用之间保持存活。
+type Encoder struct {
+	dyn            *dynamicTable
+	huffman        bool
+	maxSizeChanged bool
+}
+
+// NewEncoder 创建一个使用默认动态表大小、默认启用 Huffman 编码的 Encoder。
+func NewEncoder() *Encoder {
+	return &Encoder{
+		dyn:     newDynamicTable(DefaultMaxDynamicTableSize),
+		huffman: true,
+	}
+}
+
+// SetMaxDynamicTableSize 根据对端通告的 SETTINGS_HEADER_TABLE_SIZE 调整编码器
+// 动态表的上限；下一次 Encode 会在头部块前插入一个 Dynamic Table Size Update。
+func (e *Encoder) SetMaxDynamicTableSize(size uint32) {
+	e.dyn.setMaxSize(size)
+	e.maxSizeChanged = true
+}
+
+// SetHuffman 控制字符串字面量是否优先使用 Huffman 编码（仅在编码后更短时采用）。
+func (e *Encoder) SetHuffman(enabled bool) {
+	e.huffman = enabled
+}
+
+// Encode 把 fields 编码为一段 HPACK 头部块。
+func (e *Encoder) Encode(fields []HeaderField) ([]byte, error) {
+	var dst []byte
+	if e.maxSizeChanged {
+		dst = append(dst, 0x20)
+		dst = appendVarInt(dst, 5, uint64(e.dyn.maxSize))
+		e.maxSizeChanged = false
+	}
+	for _, f := range fields {
+		dst = e.encodeField(dst, f)
+	}
+	return dst, nil
+}
+
+func (e *Encoder) encodeField(dst []byte, f HeaderField) []byte {
+	index, exact := lookupIndex(e.dyn, f)
+
+	if exact && !f.Sensitive {
+		dst = append(dst, 0x80)
+		return appendVarInt(dst, 7, uint64(index))
+	}
+
+	if f.Sensitive {
+		dst = append(dst, 0x10)
+		dst = appendVarInt(dst, 4, uint64(index))
+		if index == 0 {
+			dst = e.appendString(dst, f.Name)
+		}
+		return e.appendString(dst, f.Value)
+	}
+
+	// 字面量 + 增量索引：编码后把字段插入动态表，供后续帧复用。
+	dst = append(dst, 0x40)
+	dst = appendVarInt(dst, 6, uint64(index))
+	if index == 0 {
+		dst = e.appendString(dst, f.Name)
+	}
+	dst = e.appendString(dst, f.Value)
+	e.dyn.add(f)
+	return dst
+}
+
+func (e *Encoder) appendString(dst []byte, s string) []byte {
+	if e.huffman {
+		if hlen := HuffmanEncodedLen(s); hlen < len(s) {
+			dst = append(dst, 0x80)
+			dst = appendVarInt(dst, 7, uint64(hlen))
+			return HuffmanAppend(dst, s)
+		}
+	}
+	dst = append(dst, 0x00)
+	dst = appendVarInt(dst, 7, uint64(len(s)))
+	return append(dst, s...)
+}
@@ -0,0 +1,166 @@
+// Package hpack 实现 RFC 7541 定义的 HPACK 头部压缩算法，
+// 供 http2.Framer 在编解码 HEADERS 帧的 HeaderBlock 时使用。
+package hpack
+
+// HeaderField 是一个已解码的头部字段。Sensitive 为 true 时表示该字段
+// 不应被加入动态表（对应"Never Indexed"表示形式），常用于敏感头部如 Cookie。
+type HeaderField struct {
+	Name      string
+	Value     string
+	Sensitive bool
+}
+
+// Size 按 RFC 7541 §4.1 的定义计算该字段计入动态表大小的代价：
+// 名称和值的字节数之和再加上 32 字节的条目开销。
+func (f HeaderField) Size() int {
+	return len(f.Name) + len(f.Value) + 32
+}
+
+// DefaultMaxDynamicTableSize 是连接建立时动态表的默认上限（RFC 7541 §4.2）。
+const DefaultMaxDynamicTableSize = 4096
+
+// staticTable 是 RFC 7541 附录 A 定义的静态表，索引从 1 开始。
+var staticTable = []HeaderField{
+	{Name: ":authority"},
+	{Name: ":method", Value: "GET"},
+	{Name: ":method", Value: "POST"},
+	{Name: ":path", Value: "/"},
+	{Name: ":path", Value: "/index.html"},
+	{Name: ":scheme", Value: "http"},
+	{Name: ":scheme", Value: "https"},
+	{Name: ":status", Value: "200"},
+	{Name: ":status", Value: "204"},
+	{Name: ":status", Value: "206"},
+	{Name: ":status", Value: "304"},
+	{Name: ":status", Value: "400"},
+	{Name: ":status", Value: "404"},
+	{Name: ":status", Value: "500"},
+	{Name: "accept-charset"},
+	{Name: "accept-encoding", Value: "gzip, deflate"},
+	{Name: "accept-language"},
+	{Name: "accept-ranges"},
+	{Name: "accept"},
+	{Name: "access-control-allow-origin"},
+	{Name: "age"},
+	{Name: "allow"},
+	{Name: "authorization"},
+	{Name: "cache-control"},
+	{Name: "content-disposition"},
+	{Name: "content-encoding"},
+	{Name: "content-language"},
+	{Name: "content-length"},
+	{Name: "content-location"},
+	{Name: "content-range"},
+	{Name: "content-type"},
+	{Name: "cookie"},
+	{Name: "date"},
+	{Name: "etag"},
+	{Name: "expect"},
+	{Name: "expires"},
+	{Name: "from"},
+	{Name: "host"},
+	{Name: "if-match"},
+	{Name: "if-modified-since"},
+	{Name: "if-none-match"},
+	{Name: "if-range"},
+	{Name: "if-unmodified-since"},
+	{Name: "last-modified"},
+	{Name: "link"},
+	{Name: "location"},
+	{Name: "max-forwards"},
+	{Name: "proxy-authenticate"},
+	{Name: "proxy-authorization"},
+	{Name: "range"},
+	{Name: "referer"},
+	{Name: "refresh"},
+	{Name: "retry-after"},
+	{Name: "server"},
+	{Name: "set-cookie"},
+	{Name: "strict-transport-security"},
+	{Name: "transfer-encoding"},
+	{Name: "user-agent"},
+	{Name: "vary"},
+	{Name: "via"},
+	{Name: "www-authenticate"},
+}
+
+// dynamicTable 是每个编解码方向各自维护的动态表（RFC 7541 §2.3.2）。
+// entries[0] 是最近插入的条目，新条目从前面插入，容量不足时从末尾淘汰。
+type dynamicTable struct {
+	entries []HeaderField
+	size    int // 当前占用大小（各条目 Size() 之和）
+	maxSize uint32
+}
+
+func newDynamicTable(maxSize uint32) *dynamicTable {
+	return &dynamicTable{maxSize: maxSize}
+}
+
+// setMaxSize 调整动态表允许的最大大小，必要时淘汰条目直至满足新的上限。
+func (t *dynamicTable) setMaxSize(maxSize uint32) {
+	t.maxSize = maxSize
+	t.evict()
+}
+
+// add 将一个字段插入动态表最前面，必要时淘汰旧条目腾出空间。
+// 若字段自身大小就超过 maxSize，则动态表被清空且该字段不会被加入（RFC 7541 §4.4）。
+func (t *dynamicTable) add(f HeaderField) {
+	t.entries = append([]HeaderField{f}, t.entries...)
+	t.size += f.Size()
+	t.evict()
+}
+
+func (t *dynamicTable) evict() {
+	for t.size > int(t.maxSize) && len(t.entries) > 0 {
+		last := t.entries[len(t.entries)-1]
+		t.entries = t.entries[:len(t.entries)-1]
+		t.size -= last.Size()
+	}
+}
+
+// at 返回动态表中 0-based 偏移 i 处的条目（i=0 为最近插入的条目）。
+func (t *dynamicTable) at(i int) (HeaderField, bool) {
+	if i < 0 || i >= len(t.entries) {
+		return HeaderField{}, false
+	}
+	return t.entries[i], true
+}
+
+// lookupIndex 在静态表后跟动态表组成的统一索引空间中查找 f，
+// 优先返回名称和值都匹配的条目，否则退化为仅名称匹配。
+// 返回的 exact 指明匹配是否同时命中了值。
+func lookupIndex(dyn *dynamicTable, f HeaderField) (index int, exact bool) {
+	nameOnly := 0
+	for i, e := range staticTable {
+		if e.Name == f.Name {
+			if e.Value == f.Value {
+				return i + 1, true
+			}
+			if nameOnly == 0 {
+				nameOnly = i + 1
+			}
+		}
+	}
+	for i, e := range dyn.entries {
+		if e.Name == f.Name {
+			if e.Value == f.Value {
+				return len(staticTable) + i + 1, true
+			}
+			if nameOnly == 0 {
+				nameOnly = len(staticTable) + i + 1
+			}
+		}
+	}
+	return nameOnly, false
+}
+
+// entryAt 返回统一索引空间（1-based）中的条目。
+func entryAt(dyn *dynamicTable, index int) (HeaderField, bool) {
+	if index < 1 {
+		return HeaderField{}, false
+	}
+	if index <= len(staticTable) {
+		return staticTable[index-1], true
+	}
+	return dyn.at(index - len(staticTable) - 1)
+}
@@ -0,0 +1,47 @@
+package hpack
+
+import "fmt"
+
+// appendVarInt 按 RFC 7541 §5.1 的 N 位前缀方案把整数 i 追加写入 dst。
+// dst 的最后一个字节必须已经写好除低 n 位之外的其余标志位；n 取值范围是 1-8。
+func appendVarInt(dst []byte, n byte, i uint64) []byte {
+	prefixMax := uint64(1<<n) - 1
+	if i < prefixMax {
+		dst[len(dst)-1] |= byte(i)
+		return dst
+	}
+	dst[len(dst)-1] |= byte(prefixMax)
+	i -= prefixMax
+	for i >= 0x80 {
+		dst = append(dst, byte(i&0x7f|0x80))
+		i >>= 7
+	}
+	return append(dst, byte(i))
+}
+
+// readVarInt 按同样的方案解码整数，p 必须从前缀字节（已去除其他标志位，仅留低 n 位）开始。
+// 返回解码出的整数和消耗的字节数。
+func readVarInt(n byte, p []byte) (i uint64, consumed int, err error) {
+	if len(p) == 0 {
+		return 0, 0, fmt.Errorf("hpack: empty input decoding integer")
+	}
+	prefixMax := uint64(1<<n) - 1
+	i = uint64(p[0]) & prefixMax
+	if i < prefixMax {
+		return i, 1, nil
+	}
+
+	var m uint
+	for idx := 1; idx < len(p); idx++ {
+		b := p[idx]
+		i += uint64(b&0x7f) << m
+		m += 7
+		if b&0x80 == 0 {
+			return i, idx + 1, nil
+		}
+		if m > 63 {
+			return 0, 0, fmt.Errorf("hpack: integer encoding too large")
+		}
+	}
+	return 0, 0, fmt.Errorf("hpack: truncated integer")
+}
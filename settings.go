@@ -0,0 +1,154 @@
+package http2
+
+import (
+	"fmt"
+
+	"github.com/shgang97/http2/hpack"
+)
+
+// SettingID 是 SETTINGS 帧里一个参数的标识符（RFC 7540 §6.5.2）。
+type SettingID uint16
+
+const (
+	SettingsHeaderTableSize      SettingID = 0x1
+	SettingsEnablePush           SettingID = 0x2
+	SettingsMaxConcurrentStreams SettingID = 0x3
+	SettingsInitialWindowSize    SettingID = 0x4
+	SettingsMaxFrameSize         SettingID = 0x5
+	SettingsMaxHeaderListSize    SettingID = 0x6
+)
+
+// Settings 是 SETTINGS 帧参数的类型化视图，每个取值都通过 (value, present)
+// 的形式暴露——present 为 false 时表示对端从未设置过该参数，应当使用协议默认值。
+type Settings struct {
+	values map[SettingID]uint32
+}
+
+// NewSettings 创建一个没有设置任何参数的 Settings。
+func NewSettings() *Settings {
+	return &Settings{values: make(map[SettingID]uint32)}
+}
+
+// DefaultSettings 返回 RFC 7540 §6.5.2 规定了初始默认值的那些参数。
+// MaxConcurrentStreams 和 MaxHeaderListSize 没有定义默认值（代表“无限制”），因此保持未设置。
+func DefaultSettings() *Settings {
+	s := NewSettings()
+	s.SetHeaderTableSize(hpack.DefaultMaxDynamicTableSize)
+	s.SetEnablePush(true)
+	s.SetInitialWindowSize(InitialWindowSize)
+	s.SetMaxFrameSize(MaxFrameSize)
+	return s
+}
+
+func (s *Settings) HeaderTableSize() (uint32, bool) {
+	v, ok := s.values[SettingsHeaderTableSize]
+	return v, ok
+}
+
+func (s *Settings) SetHeaderTableSize(v uint32) {
+	s.values[SettingsHeaderTableSize] = v
+}
+
+func (s *Settings) EnablePush() (bool, bool) {
+	v, ok := s.values[SettingsEnablePush]
+	return v != 0, ok
+}
+
+func (s *Settings) SetEnablePush(v bool) {
+	if v {
+		s.values[SettingsEnablePush] = 1
+	} else {
+		s.values[SettingsEnablePush] = 0
+	}
+}
+
+func (s *Settings) MaxConcurrentStreams() (uint32, bool) {
+	v, ok := s.values[SettingsMaxConcurrentStreams]
+	return v, ok
+}
+
+func (s *Settings) SetMaxConcurrentStreams(v uint32) {
+	s.values[SettingsMaxConcurrentStreams] = v
+}
+
+func (s *Settings) InitialWindowSize() (uint32, bool) {
+	v, ok := s.values[SettingsInitialWindowSize]
+	return v, ok
+}
+
+func (s *Settings) SetInitialWindowSize(v uint32) {
+	s.values[SettingsInitialWindowSize] = v
+}
+
+func (s *Settings) MaxFrameSize() (uint32, bool) {
+	v, ok := s.values[SettingsMaxFrameSize]
+	return v, ok
+}
+
+func (s *Settings) SetMaxFrameSize(v uint32) {
+	s.values[SettingsMaxFrameSize] = v
+}
+
+func (s *Settings) MaxHeaderListSize() (uint32, bool) {
+	v, ok := s.values[SettingsMaxHeaderListSize]
+	return v, ok
+}
+
+func (s *Settings) SetMaxHeaderListSize(v uint32) {
+	s.values[SettingsMaxHeaderListSize] = v
+}
+
+// ToSettings 把当前设置的参数按标识符大小排序展开成一组 Setting，供 NewSettingsFrame 使用。
+func (s *Settings) ToSettings() []Setting {
+	ids := make([]SettingID, 0, len(s.values))
+	for id := range s.values {
+		ids = append(ids, id)
+	}
+	for i := 1; i < len(ids); i++ {
+		for j := i; j > 0 && ids[j-1] > ids[j]; j-- {
+			ids[j-1], ids[j] = ids[j], ids[j-1]
+		}
+	}
+	settings := make([]Setting, 0, len(ids))
+	for _, id := range ids {
+		settings = append(settings, Setting{ID: id, Value: s.values[id]})
+	}
+	return settings
+}
+
+// SettingsFromList 把 SETTINGS 帧里原始的参数列表转换成类型化的 Settings，
+// 按 RFC 7540 §6.5.2 校验已知参数的取值，未知的标识符被静默忽略。
+func SettingsFromList(list []Setting) (*Settings, error) {
+	s := NewSettings()
+	for _, item := range list {
+		if err := validateSetting(item.ID, item.Value); err != nil {
+			return nil, err
+		}
+		switch item.ID {
+		case SettingsHeaderTableSize, SettingsEnablePush, SettingsMaxConcurrentStreams,
+			SettingsInitialWindowSize, SettingsMaxFrameSize, SettingsMaxHeaderListSize:
+			s.values[item.ID] = item.Value
+		}
+	}
+	return s, nil
+}
+
+// validateSetting 校验一个已知 SETTINGS 参数的取值是否符合 RFC 7540 的约束，
+// 不认识的 ID 直接放行——按 §6.5.2 的要求，未知参数必须被忽略而不是报错。
+func validateSetting(id SettingID, value uint32) error {
+	switch id {
+	case SettingsEnablePush:
+		if value != 0 && value != 1 {
+			return &FrameError{Code: ProtocolError, Msg: fmt.Sprintf("SETTINGS_ENABLE_PUSH must be 0 or 1, got %d", value)}
+		}
+	case SettingsInitialWindowSize:
+		if value > 1<<31-1 {
+			return &FrameError{Code: FlowControlError, Msg: fmt.Sprintf("SETTINGS_INITIAL_WINDOW_SIZE out of range: %d", value)}
+		}
+	case SettingsMaxFrameSize:
+		if value < MaxFrameSize || value > 0xFFFFFF {
+			return &FrameError{Code: ProtocolError, Msg: fmt.Sprintf("SETTINGS_MAX_FRAME_SIZE out of range: %d", value)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package http2
+
+// StreamID 是一个 HTTP/2 流标识符。底层仍然是 uint32（高位保留位始终为 0），
+// 这样既有按 uint32 处理流 ID 的代码不需要改动即可继续工作。
+type StreamID uint32
+
+// NewStreamID 构造一个 StreamID，并清除 RFC 7540 §4.1 保留的最高位。
+func NewStreamID(id uint32) StreamID {
+	return StreamID(id & 0x7FFFFFFF)
+}
+
+// IsZero 返回该流 ID 是否为连接级别的 0 号流。
+func (id StreamID) IsZero() bool {
+	return id == 0
+}
+
+// IsClient 返回该流 ID 是否是客户端发起的流（奇数，且非 0）。
+func (id StreamID) IsClient() bool {
+	return id != 0 && id%2 == 1
+}
+
+// IsServer 返回该流 ID 是否是服务端发起的流（偶数，且非 0）。
+func (id StreamID) IsServer() bool {
+	return id != 0 && id%2 == 0
+}